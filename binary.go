@@ -0,0 +1,153 @@
+package rope
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+//binaryFormatVersion identifies the framed binary format written by
+//WriteBinary and understood by ReadBinary
+const binaryFormatVersion byte = 1
+
+const (
+	leafTag byte = iota
+	nodeTag
+)
+
+//MarshalBinary encodes rope in the compact binary format described at
+//WriteBinary, implementing encoding.BinaryMarshaler
+func (rope *Rope) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := rope.WriteBinary(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+//WriteBinary writes rope to w in a compact framed format: a header with
+//the format version and rope's total rune length, followed by a pre-order
+//stream of node records. A leaf record is {leafTag, runeLen varint, its
+//UTF-8 bytes}; an internal node record is {nodeTag, leftLen varint}
+//followed by its left and right subtrees. leftLen is informational only -
+//ReadBinary does not rely on it to parse the stream.
+func (rope *Rope) WriteBinary(w io.Writer) error {
+	if _, err := w.Write([]byte{binaryFormatVersion}); err != nil {
+		return err
+	}
+	if err := writeUvarint(w, uint64(rope.Len())); err != nil {
+		return err
+	}
+	if rope.Len() == 0 {
+		return nil
+	}
+	return rope.writeNode(w)
+}
+
+func writeUvarint(w io.Writer, v uint64) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+func (rope *Rope) writeNode(w io.Writer) error {
+	if rope.isLeaf() {
+		if _, err := w.Write([]byte{leafTag}); err != nil {
+			return err
+		}
+		if err := writeUvarint(w, uint64(len(rope.value))); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, string(rope.value))
+		return err
+	}
+	if _, err := w.Write([]byte{nodeTag}); err != nil {
+		return err
+	}
+	if err := writeUvarint(w, uint64(rope.left.Len())); err != nil {
+		return err
+	}
+	if err := rope.left.writeNode(w); err != nil {
+		return err
+	}
+	return rope.right.writeNode(w)
+}
+
+//UnmarshalBinary decodes data written by MarshalBinary/WriteBinary back
+//into a rope. The returned rope is rebuilt into a balanced tree via the
+//same Fibonacci packing Rebalance uses, rather than reproducing the
+//original, possibly imbalanced, shape.
+func UnmarshalBinary(data []byte) (*Rope, error) {
+	return ReadBinary(bytes.NewReader(data))
+}
+
+//ReadBinary reads the format written by WriteBinary from r and
+//reconstructs a balanced rope from it
+func ReadBinary(r io.Reader) (*Rope, error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	version, err := br.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != binaryFormatVersion {
+		return nil, fmt.Errorf("rope: unsupported binary format version %d", version)
+	}
+	totalLen, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if totalLen == 0 {
+		return nil, nil
+	}
+	slots := make([]*Rope, maxFibSlots)
+	if err := readNode(br, slots); err != nil {
+		return nil, err
+	}
+	result := concatSlots(slots)
+	if uint64(result.Len()) != totalLen {
+		return nil, fmt.Errorf("rope: corrupt binary data: expected length %d, got %d", totalLen, result.Len())
+	}
+	return result, nil
+}
+
+func readNode(br *bufio.Reader, slots []*Rope) error {
+	tag, err := br.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch tag {
+	case leafTag:
+		n, err := binary.ReadUvarint(br)
+		if err != nil {
+			return err
+		}
+		value := make([]rune, n)
+		for i := uint64(0); i < n; i++ {
+			c, _, err := br.ReadRune()
+			if err != nil {
+				return err
+			}
+			value[i] = c
+		}
+		insertIntoSlots(slots, newLeaf(value))
+		return nil
+	case nodeTag:
+		//leftLen is part of the format but unused here: the reconstructed
+		//tree is rebalanced rather than mirroring the original shape.
+		if _, err := binary.ReadUvarint(br); err != nil {
+			return err
+		}
+		if err := readNode(br, slots); err != nil {
+			return err
+		}
+		return readNode(br, slots)
+	default:
+		return fmt.Errorf("rope: corrupt binary data: unknown tag %d", tag)
+	}
+}
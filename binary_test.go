@@ -0,0 +1,129 @@
+package rope
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+//Test that MarshalBinary/UnmarshalBinary round-trip rope content
+func TestMarshalUnmarshalBinary(t *testing.T) {
+	r := New("hello, ").Concat(New("wörld!"))
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal("Error in MarshalBinary: ", err)
+	}
+	r2, err := UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal("Error in UnmarshalBinary: ", err)
+	}
+	if r2.String() != r.String() {
+		t.Error("Error round-tripping binary content: ", r2.String())
+	}
+}
+
+//Test that WriteBinary/ReadBinary round-trip through an io.Writer/Reader
+func TestWriteReadBinary(t *testing.T) {
+	r := New("hello, world!")
+	var buf bytes.Buffer
+	if err := r.WriteBinary(&buf); err != nil {
+		t.Fatal("Error in WriteBinary: ", err)
+	}
+	r2, err := ReadBinary(&buf)
+	if err != nil {
+		t.Fatal("Error in ReadBinary: ", err)
+	}
+	if r2.String() != r.String() {
+		t.Error("Error round-tripping via WriteBinary/ReadBinary: ", r2.String())
+	}
+}
+
+//Test that UnmarshalBinary rebuilds a balanced tree even when the
+//original rope was a deeply right-leaning chain
+func TestUnmarshalBinaryIsBalanced(t *testing.T) {
+	var r *Rope
+	for i := 0; i < 2000; i++ {
+		r = r.concat(New("x"))
+	}
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal("Error in MarshalBinary: ", err)
+	}
+	r2, err := UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal("Error in UnmarshalBinary: ", err)
+	}
+	if r2.String() != r.String() {
+		t.Error("Error: UnmarshalBinary changed rope content")
+	}
+	maxDepth := 2 * int(math.Ceil(math.Log2(float64(r2.Len()+1))))
+	if r2.Depth() > maxDepth {
+		t.Error("Unmarshaled rope is too deep: ", r2.Depth(), "> ", maxDepth)
+	}
+}
+
+//Test that round-tripping a rope built from mixed-size, automatically-
+//rebalanced chunks preserves content. TestUnmarshalBinaryIsBalanced only
+//concatenates uniform single-"x" leaves, which can't detect the leaves
+//being fed out of order into insertIntoSlots during reconstruction.
+func TestMarshalUnmarshalBinaryMixedSizes(t *testing.T) {
+	var r *Rope
+	var want []byte
+	sizes := []int{1, 3, 2, 5, 1, 8, 3, 13, 1, 2}
+	for i := 0; i < 300; i++ {
+		n := sizes[i%len(sizes)]
+		c := make([]byte, n)
+		for j := 0; j < n; j++ {
+			c[j] = byte('a' + (i+j)%26)
+		}
+		pos := 0
+		if len(want) > 0 {
+			pos = (i * 7) % (len(want) + 1)
+		}
+		if r == nil {
+			r = New(string(c))
+		} else {
+			r = r.Insert(pos, string(c))
+		}
+		newWant := make([]byte, 0, len(want)+n)
+		newWant = append(newWant, want[:pos]...)
+		newWant = append(newWant, c...)
+		newWant = append(newWant, want[pos:]...)
+		want = newWant
+	}
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal("Error in MarshalBinary: ", err)
+	}
+	r2, err := UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal("Error in UnmarshalBinary: ", err)
+	}
+	if r2.String() != string(want) {
+		t.Error("Error: binary round-trip scrambled a mixed-leaf-size rope")
+	}
+}
+
+//Test that an empty rope round-trips to nil
+func TestMarshalUnmarshalBinaryEmpty(t *testing.T) {
+	r := New("")
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatal("Error in MarshalBinary: ", err)
+	}
+	r2, err := UnmarshalBinary(data)
+	if err != nil {
+		t.Fatal("Error in UnmarshalBinary: ", err)
+	}
+	if r2.Len() != 0 {
+		t.Error("Error: expected empty rope, got length ", r2.Len())
+	}
+}
+
+//Test that ReadBinary rejects a corrupt version byte
+func TestReadBinaryBadVersion(t *testing.T) {
+	data := []byte{0xff, 0x00}
+	if _, err := UnmarshalBinary(data); err == nil {
+		t.Error("Error: expected failure decoding unsupported version")
+	}
+}
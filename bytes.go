@@ -0,0 +1,168 @@
+package rope
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+//ErrMidRune is returned by the byte-indexed API when a byte index falls in
+//the middle of a multi-byte UTF-8 sequence instead of on a rune boundary
+var ErrMidRune = errors.New("rope: byte index falls in the middle of a multi-byte rune")
+
+//runeOffsetForByteOffset returns the number of whole runes in value whose
+//UTF-8 encoding occupies exactly the first byteOffset bytes, or ErrMidRune
+//if byteOffset falls in the middle of a rune's encoding
+func runeOffsetForByteOffset(value []rune, byteOffset int) (int, error) {
+	pos := 0
+	for i, r := range value {
+		if pos == byteOffset {
+			return i, nil
+		}
+		pos += utf8.RuneLen(r)
+	}
+	if pos == byteOffset {
+		return len(value), nil
+	}
+	return 0, ErrMidRune
+}
+
+//byteIndexInLeaf returns the byte at 1-based byte position idx within
+//value's UTF-8 encoding
+func byteIndexInLeaf(value []rune, idx int) byte {
+	pos := idx - 1
+	for _, r := range value {
+		var buf [utf8.UTFMax]byte
+		n := utf8.EncodeRune(buf[:], r)
+		if pos < n {
+			return buf[pos]
+		}
+		pos -= n
+	}
+	panic("rope: byte index out of range")
+}
+
+//ByteIndex retrieves the byte at rope position idx (1-based byte offset)
+func (rope *Rope) ByteIndex(idx int) byte {
+	if rope.isLeaf() {
+		return byteIndexInLeaf(rope.value, idx)
+	} else if idx > rope.byteWeight {
+		return rope.right.ByteIndex(idx - rope.byteWeight)
+	}
+	return rope.left.ByteIndex(idx)
+}
+
+//Internal function used by SplitBytes, mirroring split but descending via
+//byteWeight instead of weight
+func (rope *Rope) splitBytes(idx int, secondRope *Rope) (*Rope, *Rope, error) {
+	if idx == rope.byteWeight {
+		var r *Rope
+		if rope.isLeaf() {
+			r = rope
+		} else {
+			r = rope.left
+		}
+		return r, rope.right, nil
+	} else if idx > rope.byteWeight {
+		newRight, secondRope, err := rope.right.splitBytes(idx-rope.byteWeight, secondRope)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &Rope{
+			weight:     rope.weight,
+			length:     rope.left.Len() + newRight.Len(),
+			byteWeight: rope.byteWeight,
+			byteLength: rope.left.ByteLen() + newRight.ByteLen(),
+			depth:      maxInt(rope.left.Depth(), newRight.Depth()) + 1,
+			lineCount:  rope.left.LineCount() + newRight.LineCount(),
+			left:       rope.left,
+			right:      newRight,
+		}, secondRope, nil
+	} else {
+		if rope.isLeaf() {
+			runeIdx, err := runeOffsetForByteOffset(rope.value, idx)
+			if err != nil {
+				return nil, nil, err
+			}
+			var lr *Rope
+			if runeIdx > 0 {
+				lr = newLeaf(rope.value[0:runeIdx])
+			}
+			secondRope = newLeaf(rope.value[runeIdx:len(rope.value)])
+			return lr, secondRope, nil
+		}
+		newLeft, secondRope, err := rope.left.splitBytes(idx, secondRope)
+		if err != nil {
+			return nil, nil, err
+		}
+		return newLeft, secondRope.Concat(rope.right), nil
+	}
+}
+
+//SplitBytes generates two ropes starting from one, splitting it at input
+//byte offset idx (1-based). It returns ErrMidRune if idx falls in the
+//middle of a multi-byte UTF-8 sequence.
+func (rope *Rope) SplitBytes(idx int) (firstRope *Rope, secondRope *Rope, err error) {
+	if rope == nil {
+		return nil, nil, nil
+	}
+	if idx <= 0 {
+		return nil, rope, nil
+	}
+	if idx >= rope.byteLength {
+		return rope, nil, nil
+	}
+	return rope.splitBytes(idx, secondRope)
+}
+
+//InsertBytes generates a new rope inserting b into the original rope at
+//byte offset idx (1-based). It returns ErrMidRune if idx falls in the
+//middle of a multi-byte UTF-8 sequence.
+func (rope *Rope) InsertBytes(idx int, b []byte) (*Rope, error) {
+	if rope == nil {
+		return New(string(b)), nil
+	}
+	if idx < 0 {
+		rope.InsertBytes(0, b)
+	}
+	if idx > rope.byteLength {
+		rope.InsertBytes(rope.byteLength, b)
+	}
+	r1, r2, err := rope.SplitBytes(idx)
+	if err != nil {
+		return nil, err
+	}
+	return r1.Concat(New(string(b))).Concat(r2), nil
+}
+
+//DeleteBytes generates a new rope by deleting length bytes from the
+//original one starting at byte offset idx (1-based). It returns ErrMidRune
+//if either boundary falls in the middle of a multi-byte UTF-8 sequence.
+func (rope *Rope) DeleteBytes(idx int, length int) (*Rope, error) {
+	r1, r2, err := rope.SplitBytes(idx - 1)
+	if err != nil {
+		return nil, err
+	}
+	_, r4, err := r2.SplitBytes(length)
+	if err != nil {
+		return nil, err
+	}
+	return r1.Concat(r4), nil
+}
+
+//ReportBytes returns the UTF-8 encoding of length bytes of the rope
+//starting from byte offset idx included (1-based). It returns ErrMidRune
+//if either boundary falls in the middle of a multi-byte UTF-8 sequence.
+func (rope *Rope) ReportBytes(idx int, length int) ([]byte, error) {
+	if rope == nil || idx > rope.ByteLen() || length < 1 {
+		return nil, nil
+	}
+	_, r1, err := rope.SplitBytes(idx - 1)
+	if err != nil {
+		return nil, err
+	}
+	r2, _, err := r1.SplitBytes(length)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(r2.String()), nil
+}
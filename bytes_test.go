@@ -0,0 +1,83 @@
+package rope
+
+import "testing"
+
+//Test ByteLen and ByteIndex against a string containing multi-byte runes
+func TestByteLenAndByteIndex(t *testing.T) {
+	r := New("héllo") //'é' (é) encodes as 2 bytes
+	if r.ByteLen() != 6 {
+		t.Error("Error in ByteLen: ", r.ByteLen(), "!= 6")
+	}
+	want := []byte("héllo")
+	for i, b := range want {
+		if got := r.ByteIndex(i + 1); got != b {
+			t.Error("Error in ByteIndex(", i+1, "): ", got, "!=", b)
+		}
+	}
+}
+
+//Test SplitBytes at a rune-aligned boundary, and that it rejects a
+//mid-rune boundary
+func TestSplitBytes(t *testing.T) {
+	r := New("héllo")
+	r1, r2, err := r.SplitBytes(3)
+	if err != nil {
+		t.Fatal("Error in SplitBytes: ", err)
+	}
+	if r1.String() != "hé" || r2.String() != "llo" {
+		t.Error("Error splitting rope by bytes: ", r1, r2)
+	}
+	if _, _, err := r.SplitBytes(2); err != ErrMidRune {
+		t.Error("Error: expected ErrMidRune splitting mid-rune, got: ", err)
+	}
+}
+
+//Test InsertBytes and DeleteBytes round-tripping a multi-byte string
+func TestInsertDeleteBytes(t *testing.T) {
+	r := New("hllo")
+	r, err := r.InsertBytes(1, []byte("é"))
+	if err != nil {
+		t.Fatal("Error in InsertBytes: ", err)
+	}
+	if r.String() != "héllo" {
+		t.Error("Error inserting bytes: ", r.String())
+	}
+	r, err = r.DeleteBytes(2, 2)
+	if err != nil {
+		t.Fatal("Error in DeleteBytes: ", err)
+	}
+	if r.String() != "hllo" {
+		t.Error("Error deleting bytes: ", r.String())
+	}
+}
+
+//Test ReportBytes returns the expected byte range
+func TestReportBytes(t *testing.T) {
+	r := New("héllo")
+	b, err := r.ReportBytes(2, 2)
+	if err != nil {
+		t.Fatal("Error in ReportBytes: ", err)
+	}
+	if string(b) != "é" {
+		t.Error("Error in ReportBytes content: ", string(b))
+	}
+	if _, err := r.ReportBytes(3, 1); err != ErrMidRune {
+		t.Error("Error: expected ErrMidRune reporting mid-rune, got: ", err)
+	}
+}
+
+//Test byte operations against a Concat-built rope whose split straddles
+//the leaf boundary, rather than a single leaf
+func TestByteOpsAcrossConcatBoundary(t *testing.T) {
+	r := New("hé").Concat(New("llo"))
+	if r.ByteLen() != 6 {
+		t.Error("Error in ByteLen across boundary: ", r.ByteLen(), "!= 6")
+	}
+	b, err := r.ReportBytes(2, 3)
+	if err != nil {
+		t.Fatal("Error in ReportBytes across boundary: ", err)
+	}
+	if string(b) != "él" {
+		t.Error("Error in ReportBytes content across boundary: ", string(b))
+	}
+}
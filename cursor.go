@@ -0,0 +1,196 @@
+package rope
+
+//Cursor is a stateful iterator over a rope's runes. It holds an explicit
+//stack of ancestor nodes down to the leaf containing the current position,
+//so advancing across a leaf boundary only climbs the stack as far as
+//needed to find the next leaf, giving amortized O(1) per rune instead of
+//the O(log n) of repeated Index calls. It is the traversal primitive
+//Report, WriteTo and future search routines can all be built on.
+type Cursor struct {
+	root  *Rope
+	stack []*Rope
+	off   int
+	pos   int
+}
+
+//Cursor returns a new Cursor positioned at rope position idx (1-based).
+//idx may be rope.Len()+1, the one-past-the-end position described at
+//Seek. An idx outside [1, rope.Len()+1] yields a cursor positioned outside
+//the rope, for which Next and Prev both return false until Seek is called
+//again.
+func (rope *Rope) Cursor(idx int) *Cursor {
+	c := &Cursor{root: rope}
+	c.Seek(idx)
+	return c
+}
+
+//Seek repositions c at rope position idx (1-based). idx may also be
+//rope.Len()+1, a valid one-past-the-end position from which Next fails
+//but Prev still works.
+func (c *Cursor) Seek(idx int) {
+	c.pos = idx
+	c.off = 0
+	c.stack = nil
+	if c.root == nil || idx < 1 || idx > c.root.Len()+1 {
+		return
+	}
+	if idx > c.root.Len() {
+		//One-past-the-end: park on the last leaf, one past its last rune,
+		//so Prev can step back into it without needing to retreat first.
+		c.descend(c.root, true)
+		c.off++
+		return
+	}
+	node := c.root
+	offset := idx
+	for {
+		c.stack = append(c.stack, node)
+		if node.isLeaf() {
+			c.off = offset - 1
+			return
+		}
+		if offset > node.weight {
+			offset -= node.weight
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+}
+
+//Pos returns c's current position (1-based)
+func (c *Cursor) Pos() int {
+	return c.pos
+}
+
+//leaf returns the leaf c is currently positioned in, or nil if c is past
+//either end of the rope
+func (c *Cursor) leaf() *Rope {
+	if len(c.stack) == 0 {
+		return nil
+	}
+	return c.stack[len(c.stack)-1]
+}
+
+//advance moves c's stack from the current leaf to the next one, climbing
+//only as far up the ancestor stack as needed, and skipping over any empty
+//leaf it lands on
+func (c *Cursor) advance() {
+	for len(c.stack) > 1 {
+		child := c.stack[len(c.stack)-1]
+		c.stack = c.stack[:len(c.stack)-1]
+		parent := c.stack[len(c.stack)-1]
+		if parent.left == child {
+			c.descend(parent.right, false)
+			if leaf := c.leaf(); leaf != nil && len(leaf.value) == 0 {
+				c.advance()
+			}
+			return
+		}
+	}
+	c.stack = nil
+}
+
+//retreat moves c's stack from the current leaf to the previous one,
+//climbing only as far up the ancestor stack as needed, and skipping over
+//any empty leaf it lands on
+func (c *Cursor) retreat() {
+	for len(c.stack) > 1 {
+		child := c.stack[len(c.stack)-1]
+		c.stack = c.stack[:len(c.stack)-1]
+		parent := c.stack[len(c.stack)-1]
+		if parent.right == child {
+			c.descend(parent.left, true)
+			if leaf := c.leaf(); leaf != nil && len(leaf.value) == 0 {
+				c.retreat()
+			}
+			return
+		}
+	}
+	c.stack = nil
+}
+
+//descend pushes node and, repeatedly, its leftmost (or rightmost, if last
+//is true) child onto the stack until it reaches a leaf, setting off to the
+//first (or last) rune of that leaf
+func (c *Cursor) descend(node *Rope, last bool) {
+	for {
+		c.stack = append(c.stack, node)
+		if node.isLeaf() {
+			if last {
+				c.off = len(node.value) - 1
+			} else {
+				c.off = 0
+			}
+			return
+		}
+		if last {
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+}
+
+//Next returns the rune at c's current position and advances c by one. The
+//second return value is false, and c is left unchanged, if c is already at
+//or past the end of the rope.
+func (c *Cursor) Next() (rune, bool) {
+	leaf := c.leaf()
+	if leaf == nil || c.off >= len(leaf.value) {
+		return 0, false
+	}
+	r := leaf.value[c.off]
+	c.pos++
+	c.off++
+	if c.off >= len(leaf.value) {
+		c.advance()
+	}
+	return r, true
+}
+
+//Prev moves c back by one and returns the rune at its new position. The
+//second return value is false, and c is left unchanged, if c is already at
+//the start of the rope.
+func (c *Cursor) Prev() (rune, bool) {
+	if c.pos <= 1 || c.pos > c.root.Len()+1 {
+		return 0, false
+	}
+	if c.leaf() == nil {
+		//Next or ReadRunes stepped off the end: park on the last leaf again
+		c.Seek(c.pos)
+	}
+	c.pos--
+	c.off--
+	if c.off < 0 {
+		c.retreat()
+	}
+	leaf := c.leaf()
+	return leaf.value[c.off], true
+}
+
+//ReadRunes fills dst with the runes starting at c's current position,
+//advancing c by the number of runes read, and returns that count. It
+//returns fewer than len(dst) runes only if c reaches the end of the rope.
+func (c *Cursor) ReadRunes(dst []rune) int {
+	n := 0
+	for n < len(dst) {
+		leaf := c.leaf()
+		if leaf == nil {
+			break
+		}
+		avail := len(leaf.value) - c.off
+		want := len(dst) - n
+		if want < avail {
+			avail = want
+		}
+		copy(dst[n:n+avail], leaf.value[c.off:c.off+avail])
+		n += avail
+		c.off += avail
+		c.pos += avail
+		if c.off >= len(leaf.value) {
+			c.advance()
+		}
+	}
+	return n
+}
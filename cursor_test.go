@@ -0,0 +1,100 @@
+package rope
+
+import "testing"
+
+//Test forward traversal with Next
+func TestCursorNext(t *testing.T) {
+	r := New("hello, ").Concat(New("world!"))
+	c := r.Cursor(1)
+	var out []rune
+	for {
+		ch, ok := c.Next()
+		if !ok {
+			break
+		}
+		out = append(out, ch)
+	}
+	if string(out) != "hello, world!" {
+		t.Error("Error in Cursor.Next traversal: ", string(out))
+	}
+}
+
+//Test backward traversal with Prev, starting past the end
+func TestCursorPrev(t *testing.T) {
+	r := New("hello, ").Concat(New("world!"))
+	c := r.Cursor(r.Len() + 1)
+	var out []rune
+	for {
+		ch, ok := c.Prev()
+		if !ok {
+			break
+		}
+		out = append([]rune{ch}, out...)
+	}
+	if string(out) != "hello, world!" {
+		t.Error("Error in Cursor.Prev traversal: ", string(out))
+	}
+}
+
+//Test that Seek repositions an existing cursor and Pos reports it
+func TestCursorSeek(t *testing.T) {
+	r := New("hello, world!")
+	c := r.Cursor(1)
+	c.Seek(8)
+	if c.Pos() != 8 {
+		t.Error("Error in Cursor.Pos after Seek: ", c.Pos())
+	}
+	ch, ok := c.Next()
+	if !ok || ch != 'w' {
+		t.Error("Error in Cursor.Next after Seek: ", ch, ok)
+	}
+	if c.Pos() != 9 {
+		t.Error("Error in Cursor.Pos after Next: ", c.Pos())
+	}
+}
+
+//Test that a cursor at or past either end reports no further runes
+func TestCursorBounds(t *testing.T) {
+	r := New("abc")
+	if _, ok := r.Cursor(1).Prev(); ok {
+		t.Error("Error: Prev at start should fail")
+	}
+	if _, ok := r.Cursor(4).Next(); ok {
+		t.Error("Error: Next past end should fail")
+	}
+	if _, ok := New("").Cursor(1).Next(); ok {
+		t.Error("Error: Next on empty rope should fail")
+	}
+}
+
+//Test ReadRunes across leaf boundaries, including a short final read
+func TestCursorReadRunes(t *testing.T) {
+	r := New("abc").Concat(New("def")).Concat(New("ghi"))
+	c := r.Cursor(2)
+	dst := make([]rune, 5)
+	if n := c.ReadRunes(dst); n != 5 || string(dst) != "bcdef" {
+		t.Error("Error in Cursor.ReadRunes: ", n, string(dst))
+	}
+	rest := make([]rune, 10)
+	if n := c.ReadRunes(rest); n != 3 || string(rest[:n]) != "ghi" {
+		t.Error("Error in Cursor.ReadRunes at end: ", n, string(rest[:n]))
+	}
+}
+
+//Test that traversal skips over an empty leaf produced by concatenating
+//an empty rope
+func TestCursorSkipsEmptyLeaf(t *testing.T) {
+	r := New("ab").Concat(New("")).Concat(New("cd"))
+	c := r.Cursor(1)
+	var out []rune
+	for {
+		ch, ok := c.Next()
+		if !ok {
+			break
+		}
+		out = append(out, ch)
+	}
+	if string(out) != "abcd" {
+		t.Error("Error in Cursor traversal across empty leaf: ", string(out))
+	}
+}
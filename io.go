@@ -0,0 +1,97 @@
+package rope
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+//defaultLeafSize is used by NewFromReader when leafSize is not positive
+const defaultLeafSize = 1024
+
+//Reader returns an io.Reader that streams the rope's text leaf by leaf,
+//without materializing the full string
+func (rope *Rope) Reader() io.Reader {
+	leaves := rope.appendLeaves(nil)
+	readers := make([]io.Reader, len(leaves))
+	for i, leaf := range leaves {
+		readers[i] = strings.NewReader(string(leaf.value))
+	}
+	return io.MultiReader(readers...)
+}
+
+//ropeRuneReader walks a rope's leaves in order, one rune at a time
+type ropeRuneReader struct {
+	leaves []*Rope
+	leaf   int
+	idx    int
+}
+
+//ReadRune implements io.RuneReader
+func (r *ropeRuneReader) ReadRune() (c rune, size int, err error) {
+	for r.leaf < len(r.leaves) {
+		value := r.leaves[r.leaf].value
+		if r.idx < len(value) {
+			c = value[r.idx]
+			r.idx++
+			return c, utf8.RuneLen(c), nil
+		}
+		r.leaf++
+		r.idx = 0
+	}
+	return 0, 0, io.EOF
+}
+
+//RuneReader returns an io.RuneReader that streams the rope's runes leaf by
+//leaf, without materializing the full string
+func (rope *Rope) RuneReader() io.RuneReader {
+	return &ropeRuneReader{leaves: rope.appendLeaves(nil)}
+}
+
+//WriteTo writes the rope's text to w leaf by leaf, without materializing
+//the full string, implementing io.WriterTo
+func (rope *Rope) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	for _, leaf := range rope.appendLeaves(nil) {
+		n, err := io.WriteString(w, string(leaf.value))
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+//NewFromReader reads r in leafSize-rune chunks and combines the resulting
+//leaves bottom-up using the same Fibonacci packing as Rebalance, so the
+//returned rope is balanced from the start rather than the right-leaning
+//chain produced by repeated Concat calls. leafSize is clamped to
+//defaultLeafSize if not positive.
+func NewFromReader(r io.Reader, leafSize int) (*Rope, error) {
+	if leafSize <= 0 {
+		leafSize = defaultLeafSize
+	}
+	br := bufio.NewReader(r)
+	slots := make([]*Rope, maxFibSlots)
+	buf := make([]rune, 0, leafSize)
+	for {
+		c, _, err := br.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, c)
+		if len(buf) == leafSize {
+			insertIntoSlots(slots, newLeaf(buf))
+			buf = make([]rune, 0, leafSize)
+		}
+	}
+	if len(buf) > 0 {
+		insertIntoSlots(slots, newLeaf(buf))
+	}
+
+	return concatSlots(slots), nil
+}
@@ -0,0 +1,76 @@
+package rope
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+//Test that Reader streams the full rope content
+func TestReader(t *testing.T) {
+	r := New("hello, ").Concat(New("world!"))
+	b, err := ioutil.ReadAll(r.Reader())
+	if err != nil {
+		t.Fatal("Error reading from Reader: ", err)
+	}
+	if string(b) != "hello, world!" {
+		t.Error("Error in Reader content: ", string(b))
+	}
+}
+
+//Test that RuneReader yields the rope's runes in order
+func TestRuneReader(t *testing.T) {
+	r := New("hello, ").Concat(New("world!"))
+	rr := r.RuneReader()
+	var out []rune
+	for {
+		c, _, err := rr.ReadRune()
+		if err != nil {
+			break
+		}
+		out = append(out, c)
+	}
+	if string(out) != "hello, world!" {
+		t.Error("Error in RuneReader content: ", string(out))
+	}
+}
+
+//Test that WriteTo writes the full rope content and reports its length
+func TestWriteTo(t *testing.T) {
+	r := New("hello, ").Concat(New("world!"))
+	var sb strings.Builder
+	n, err := r.WriteTo(&sb)
+	if err != nil {
+		t.Fatal("Error in WriteTo: ", err)
+	}
+	if n != int64(r.Len()) || sb.String() != "hello, world!" {
+		t.Error("Error in WriteTo content: ", sb.String(), n)
+	}
+}
+
+//Test that NewFromReader builds a rope matching the source text in content
+//and length
+func TestNewFromReader(t *testing.T) {
+	src := "the quick brown fox jumps over the lazy dog"
+	r, err := NewFromReader(strings.NewReader(src), 4)
+	if err != nil {
+		t.Fatal("Error in NewFromReader: ", err)
+	}
+	if r.String() != src {
+		t.Error("Error in NewFromReader content: ", r.String())
+	}
+	if r.Len() != len(src) {
+		t.Error("Error in NewFromReader length: ", r.Len(), "!=", len(src))
+	}
+}
+
+//Test that NewFromReader handles an empty reader
+func TestNewFromReaderEmpty(t *testing.T) {
+	r, err := NewFromReader(strings.NewReader(""), 4)
+	if err != nil {
+		t.Fatal("Error in NewFromReader: ", err)
+	}
+	if r.Len() != 0 {
+		t.Error("Error in NewFromReader on empty input: ", r.Len())
+	}
+}
@@ -0,0 +1,100 @@
+package rope
+
+//lineStartOffset returns the 1-based rune offset of the start of line n
+//(1-based) within rope, descending the tree using lineCount the way Index
+//uses weight. An n below 1 is treated as 1; an n above rope.LineCount()+1
+//yields rope.Len()+1.
+func (rope *Rope) lineStartOffset(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	if rope.isLeaf() {
+		target := n - 1
+		count := 0
+		for i, r := range rope.value {
+			if r == '\n' {
+				count++
+				if count == target {
+					return i + 2
+				}
+			}
+		}
+		return rope.length + 1
+	}
+	leftLines := rope.left.LineCount()
+	if n <= leftLines+1 {
+		//Line n either ends within left, or is left's trailing,
+		//not-yet-newline-terminated remainder that continues into right -
+		//either way its start lies within left, not at rope.weight+1.
+		return rope.left.lineStartOffset(n)
+	}
+	return rope.weight + rope.right.lineStartOffset(n-leftLines)
+}
+
+//newlinesBefore returns the number of '\n' runes found strictly before
+//rope position idx (1-based)
+func (rope *Rope) newlinesBefore(idx int) int {
+	if rope == nil || idx <= 1 {
+		return 0
+	}
+	if rope.isLeaf() {
+		limit := idx - 1
+		if limit > len(rope.value) {
+			limit = len(rope.value)
+		}
+		return countNewlines(rope.value[:limit])
+	}
+	if idx > rope.weight {
+		return rope.left.LineCount() + rope.right.newlinesBefore(idx-rope.weight)
+	}
+	return rope.left.newlinesBefore(idx)
+}
+
+//Line returns the n-th line (1-based) of the rope, without its trailing
+//newline
+func (rope *Rope) Line(n int) string {
+	if rope == nil || n < 1 || n > rope.LineCount()+1 {
+		return ""
+	}
+	start := rope.lineStartOffset(n)
+	if n == rope.LineCount()+1 {
+		return rope.Report(start, rope.Len()-start+1)
+	}
+	end := rope.lineStartOffset(n+1) - 1
+	return rope.Report(start, end-start)
+}
+
+//LineRange returns the sub-rope spanning lines start through end
+//(1-based, inclusive), newlines included
+func (rope *Rope) LineRange(start, end int) *Rope {
+	if rope == nil || start < 1 || end < start {
+		return nil
+	}
+	lastLine := rope.LineCount() + 1
+	if start > lastLine {
+		return nil
+	}
+	if end > lastLine {
+		end = lastLine
+	}
+	from := rope.lineStartOffset(start)
+	if end == lastLine {
+		return rope.Substr(from, rope.Len()-from+1)
+	}
+	to := rope.lineStartOffset(end + 1)
+	return rope.Substr(from, to-from)
+}
+
+//OffsetToLineCol converts a 1-based rune offset into its 1-based line and
+//column
+func (rope *Rope) OffsetToLineCol(idx int) (line, col int) {
+	line = rope.newlinesBefore(idx) + 1
+	col = idx - rope.lineStartOffset(line) + 1
+	return
+}
+
+//LineColToOffset converts a 1-based line and column into a 1-based rune
+//offset
+func (rope *Rope) LineColToOffset(line, col int) int {
+	return rope.lineStartOffset(line) + col - 1
+}
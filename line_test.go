@@ -0,0 +1,69 @@
+package rope
+
+import "testing"
+
+//Test line counting and retrieval
+func TestLineCountAndLine(t *testing.T) {
+	r := New("abc\ndef\nghi")
+	if r.LineCount() != 2 {
+		t.Error("Error counting lines: ", r.LineCount(), "!= 2")
+	}
+	if r.Line(1) != "abc" || r.Line(2) != "def" || r.Line(3) != "ghi" {
+		t.Error("Error retrieving lines: ", r.Line(1), r.Line(2), r.Line(3))
+	}
+	if r.Line(4) != "" {
+		t.Error("Error retrieving out-of-range line: ", r.Line(4))
+	}
+}
+
+//Test that a split landing inside a leaf that contains newlines keeps
+//lineCount correct on both halves
+func TestLineCountAcrossSplit(t *testing.T) {
+	r := New("abc\ndef\nghi")
+	r1, r2 := r.Split(5)
+	if r1.LineCount() != 1 || r2.LineCount() != 1 {
+		t.Error("Error maintaining lineCount across split: ", r1.LineCount(), r2.LineCount())
+	}
+	if r1.String() != "abc\nd" || r2.String() != "ef\nghi" {
+		t.Error("Error splitting rope with newlines: ", r1, r2)
+	}
+}
+
+//Test LineRange
+func TestLineRange(t *testing.T) {
+	r := New("abc\ndef\nghi")
+	if lr := r.LineRange(2, 3); lr.String() != "def\nghi" {
+		t.Error("Error in LineRange(2,3): ", lr)
+	}
+	if lr := r.LineRange(1, 1); lr.String() != "abc\n" {
+		t.Error("Error in LineRange(1,1): ", lr)
+	}
+}
+
+//Test offset/line-col conversions
+func TestOffsetLineColConversions(t *testing.T) {
+	r := New("abc\ndef\nghi")
+	line, col := r.OffsetToLineCol(6)
+	if line != 2 || col != 2 {
+		t.Error("Error in OffsetToLineCol: ", line, col, "!= 2, 2")
+	}
+	if off := r.LineColToOffset(2, 2); off != 6 {
+		t.Error("Error in LineColToOffset: ", off, "!= 6")
+	}
+}
+
+//Test that a line straddling a Concat boundary - where the left leaf's
+//text does not end on a newline - is still resolved correctly, rather
+//than assuming the line starts at the boundary itself
+func TestLineAcrossConcatBoundary(t *testing.T) {
+	r := New("ab\ncd").Concat(New("ef"))
+	if r.LineCount() != 1 {
+		t.Error("Error counting lines across boundary: ", r.LineCount(), "!= 1")
+	}
+	if r.Line(2) != "cdef" {
+		t.Error("Error retrieving line straddling boundary: ", r.Line(2))
+	}
+	if line, col := r.OffsetToLineCol(5); line != 2 || col != 2 {
+		t.Error("Error in OffsetToLineCol across boundary: ", line, col, "!= 2, 2")
+	}
+}
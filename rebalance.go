@@ -0,0 +1,140 @@
+package rope
+
+//rebalanceThreshold is the tree depth above which Concat/Insert trigger an
+//automatic rebalance, following the Boehm/Atkinson/Plass rope algorithm.
+const rebalanceThreshold = 32
+
+//maxFibSlots bounds the slot array used by Rebalance. It is comfortably
+//larger than any depth a rebalance would ever produce, while keeping every
+//Fibonacci number in the table, up to Fib(maxFibSlots+2), within the range
+//of a 64-bit int.
+const maxFibSlots = 90
+
+//fibonacci caches Fib(n) for every index fib can be asked for. It is built
+//once at init time - rather than extended lazily on demand - so that the
+//concurrent calls to fib from Rebalance/Concat/NewFromReader/ReadBinary
+//only ever read it.
+var fibonacci = buildFibonacci()
+
+func buildFibonacci() []int {
+	table := make([]int, maxFibSlots+3)
+	table[0], table[1] = 0, 1
+	for i := 2; i < len(table); i++ {
+		table[i] = table[i-1] + table[i-2]
+	}
+	return table
+}
+
+//fib returns the n-th Fibonacci number (Fib(0)=0, Fib(1)=1)
+func fib(n int) int {
+	return fibonacci[n]
+}
+
+//isBalanced reports whether rope satisfies the Fibonacci balance invariant:
+//a rope of depth d is balanced iff its length is at least Fib(d+2).
+func (rope *Rope) isBalanced() bool {
+	if rope == nil {
+		return true
+	}
+	d := rope.Depth() + 2
+	if d >= len(fibonacci) {
+		//Fib(d) is larger than any length an int can hold
+		return false
+	}
+	return rope.Len() >= fib(d)
+}
+
+//needsRebalance reports whether rope's depth is high enough to warrant a
+//call to Rebalance, either because it crossed the hard threshold or because
+//it no longer satisfies the Fibonacci balance invariant.
+func (rope *Rope) needsRebalance() bool {
+	if rope == nil || rope.isLeaf() {
+		return false
+	}
+	return rope.Depth() > rebalanceThreshold || !rope.isBalanced()
+}
+
+//Rebalance returns a new rope holding the same string as rope, rebuilt into
+//a balanced tree using the classic Fibonacci/Boehm algorithm: rope is
+//visited in order and its already-balanced subtrees (down to individual
+//leaves) are packed into slots[i], each holding a subtree whose length lies
+//in [Fib(i+2), Fib(i+3)). Subtrees that already satisfy the invariant are
+//packed whole rather than decomposed into leaves, so rebalancing a tree
+//that is unbalanced only near the root - the common case when this is
+//triggered automatically from Concat - stays cheap however large the rope
+//already is.
+func (rope *Rope) Rebalance() *Rope {
+	if rope == nil || rope.isLeaf() {
+		return rope
+	}
+	slots := make([]*Rope, maxFibSlots)
+	rope.packIntoSlots(slots)
+	return concatSlots(slots)
+}
+
+//concatSlots merges a Fibonacci slot array back into a single rope. Slots
+//are visited from the highest index down to the lowest: a lower slot holds
+//a smaller fragment buffered more recently than whatever already sits in a
+//higher slot, so reassembling left-to-right text order means appending
+//each slot's content after the taller ones, not before.
+func concatSlots(slots []*Rope) *Rope {
+	var result *Rope
+	for i := len(slots) - 1; i >= 0; i-- {
+		result = result.concat(slots[i])
+	}
+	return result
+}
+
+//packIntoSlots feeds rope into slots in left-to-right order, packing it
+//whole as soon as it is a leaf or already balanced, and otherwise
+//recursing into its children.
+func (rope *Rope) packIntoSlots(slots []*Rope) {
+	if rope == nil {
+		return
+	}
+	if rope.isLeaf() || rope.isBalanced() {
+		insertIntoSlots(slots, rope)
+		return
+	}
+	rope.left.packIntoSlots(slots)
+	rope.right.packIntoSlots(slots)
+}
+
+//slotFor returns the smallest i such that length < Fib(i+3)
+func slotFor(length int) int {
+	i := 0
+	for i < maxFibSlots-1 && length >= fib(i+3) {
+		i++
+	}
+	return i
+}
+
+//insertIntoSlots inserts chunk (a leaf or an already-balanced subtree) into
+//slots following the Fibonacci packing rule, concatenating any lower slots
+//into chunk first and cascading the result up into higher slots as needed.
+//Lower slots are merged highest-index-first, matching concatSlots: a lower
+//slot can hold a fragment buffered more recently (further right in the
+//text) than whatever already sits in a higher slot.
+func insertIntoSlots(slots []*Rope, chunk *Rope) {
+	i := slotFor(chunk.Len())
+	var combined *Rope
+	for j := i - 1; j >= 0; j-- {
+		if slots[j] != nil {
+			combined = combined.concat(slots[j])
+			slots[j] = nil
+		}
+	}
+	combined = combined.concat(chunk)
+
+	for {
+		if slots[i] != nil {
+			combined = slots[i].concat(combined)
+			slots[i] = nil
+		}
+		if i == maxFibSlots-1 || combined.Len() < fib(i+3) {
+			slots[i] = combined
+			return
+		}
+		i++
+	}
+}
@@ -0,0 +1,84 @@
+package rope
+
+import (
+	"math"
+	"testing"
+)
+
+//Test that Rebalance flattens a right-leaning chain into a balanced tree
+func TestRebalance(t *testing.T) {
+	var r *Rope
+	for i := 0; i < 2000; i++ {
+		r = r.concat(New("x"))
+	}
+	if r.Len() != 2000 {
+		t.Fatal("Error building rope - length fail: ", r.Len(), "!= 2000")
+	}
+	balanced := r.Rebalance()
+	if balanced.String() != r.String() {
+		t.Error("Rebalance changed the rope content")
+	}
+	maxDepth := 2 * int(math.Ceil(math.Log2(float64(balanced.Len()+1))))
+	if balanced.Depth() > maxDepth {
+		t.Error("Rebalanced rope is too deep: ", balanced.Depth(), "> ", maxDepth)
+	}
+}
+
+//Test that Rebalance preserves rune order, using a distinguishable marker
+//per leaf so a scrambled reassembly would be caught (TestRebalance uses an
+//identical "x" at every position, which can't detect reordering)
+func TestRebalancePreservesOrder(t *testing.T) {
+	var r *Rope
+	var want []byte
+	for i := 0; i < 500; i++ {
+		c := byte('a' + i%26)
+		r = r.Concat(New(string(c)))
+		want = append(want, c)
+	}
+	balanced := r.Rebalance()
+	if balanced.String() != string(want) {
+		t.Error("Rebalance scrambled rope content")
+	}
+}
+
+//Test that Rebalance preserves rune order when the slots being merged hold
+//fragments of different sizes. TestRebalancePreservesOrder only ever
+//concatenates uniform single-character leaves, which never produces a
+//lower slot holding content that belongs after a higher slot's content -
+//exactly the occupancy pattern that caught insertIntoSlots merging slots
+//in the wrong direction.
+func TestRebalancePreservesOrderMixedSizes(t *testing.T) {
+	var r *Rope
+	var want []byte
+	sizes := []int{1, 3, 2, 5, 1, 8, 3, 13, 1, 2}
+	for i := 0; i < 400; i++ {
+		n := sizes[i%len(sizes)]
+		c := make([]byte, n)
+		for j := 0; j < n; j++ {
+			c[j] = byte('a' + (i+j)%26)
+		}
+		r = r.Concat(New(string(c)))
+		want = append(want, c...)
+	}
+	balanced := r.Rebalance()
+	if balanced.String() != string(want) {
+		t.Error("Rebalance scrambled mixed-size chunk content")
+	}
+}
+
+//Test that 100k sequential inserts keep the tree depth O(log n) thanks to
+//automatic rebalancing
+func TestAutomaticRebalanceOnInsert(t *testing.T) {
+	r := New("")
+	const n = 100000
+	for i := 1; i <= n; i++ {
+		r = r.Insert(r.Len(), "x")
+	}
+	if r.Len() != n {
+		t.Fatal("Error building rope - length fail: ", r.Len(), "!=", n)
+	}
+	maxDepth := 2 * int(math.Ceil(math.Log2(float64(r.Len()+1))))
+	if r.Depth() > maxDepth {
+		t.Error("Rope depth grew too large after 100k inserts: ", r.Depth(), "> ", maxDepth)
+	}
+}
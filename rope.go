@@ -13,11 +13,15 @@ import (
 
 //Rope represents a persistent rope data structure
 type Rope struct {
-	value  []rune
-	weight int
-	length int
-	left   *Rope
-	right  *Rope
+	value      []rune
+	weight     int
+	length     int
+	byteWeight int
+	byteLength int
+	depth      int
+	lineCount  int
+	left       *Rope
+	right      *Rope
 }
 
 //isLeaf returns true if the rope is a leaf
@@ -25,13 +29,55 @@ func (rope *Rope) isLeaf() bool {
 	return rope.left == nil
 }
 
+//appendLeaves appends rope's leaves to out, in left-to-right order
+func (rope *Rope) appendLeaves(out []*Rope) []*Rope {
+	if rope == nil {
+		return out
+	}
+	if rope.isLeaf() {
+		return append(out, rope)
+	}
+	out = rope.left.appendLeaves(out)
+	return rope.right.appendLeaves(out)
+}
+
 //New returns a new rope initialized with given string
 func New(bootstrap string) *Rope {
-	len := utf8.RuneCountInString(bootstrap)
+	return newLeaf([]rune(bootstrap))
+}
+
+//newLeaf returns a new leaf rope wrapping value directly, without
+//converting it to and from a string
+func newLeaf(value []rune) *Rope {
+	len := len(value)
+	byteLen := byteLen(value)
 	return &Rope{
-		value:  []rune(bootstrap),
-		weight: len,
-		length: len}
+		value:      value,
+		weight:     len,
+		length:     len,
+		byteWeight: byteLen,
+		byteLength: byteLen,
+		lineCount:  countNewlines(value)}
+}
+
+//countNewlines returns the number of '\n' runes in value
+func countNewlines(value []rune) int {
+	count := 0
+	for _, r := range value {
+		if r == '\n' {
+			count++
+		}
+	}
+	return count
+}
+
+//byteLen returns the number of bytes value occupies when UTF-8 encoded
+func byteLen(value []rune) int {
+	count := 0
+	for _, r := range value {
+		count += utf8.RuneLen(r)
+	}
+	return count
 }
 
 //Len returns the length of the rope underlying string
@@ -42,6 +88,30 @@ func (rope *Rope) Len() int {
 	return rope.length
 }
 
+//ByteLen returns the length, in bytes, of the rope underlying string
+func (rope *Rope) ByteLen() int {
+	if rope == nil {
+		return 0
+	}
+	return rope.byteLength
+}
+
+//Depth returns the height of the rope tree, 0 for a nil rope or a leaf
+func (rope *Rope) Depth() int {
+	if rope == nil {
+		return 0
+	}
+	return rope.depth
+}
+
+//LineCount returns the number of '\n' runes in the rope
+func (rope *Rope) LineCount() int {
+	if rope == nil {
+		return 0
+	}
+	return rope.lineCount
+}
+
 //String returns the complete string stored in the rope
 func (rope *Rope) String() string {
 	return rope.Report(1, rope.length)
@@ -90,8 +160,19 @@ func (rope *Rope) Index(idx int) rune {
 	}
 }
 
-//Concat merges two ropes and generates a brand new one
+//Concat merges two ropes and generates a brand new one, rebalancing the
+//result if it has grown too deep.
 func (rope *Rope) Concat(other *Rope) *Rope {
+	concat := rope.concat(other)
+	if concat.needsRebalance() {
+		return concat.Rebalance()
+	}
+	return concat
+}
+
+//concat merges two ropes without triggering a rebalance, used both by
+//Concat and internally by Rebalance while it reassembles a balanced tree.
+func (rope *Rope) concat(other *Rope) *Rope {
 	//Special case: if the first rope is nil, just return the second rope
 	if rope == nil {
 		return other
@@ -103,11 +184,23 @@ func (rope *Rope) Concat(other *Rope) *Rope {
 	//Return a new rope with 'rope' and 'other' assigned respectively
 	//to left and right subropes.
 	return &Rope{
-		weight: rope.Len(),
-		length: rope.Len() + other.Len(),
-		left:   rope,
-		right:  other,
+		weight:     rope.Len(),
+		length:     rope.Len() + other.Len(),
+		byteWeight: rope.ByteLen(),
+		byteLength: rope.ByteLen() + other.ByteLen(),
+		depth:      maxInt(rope.Depth(), other.Depth()) + 1,
+		lineCount:  rope.LineCount() + other.LineCount(),
+		left:       rope,
+		right:      other,
+	}
+}
+
+//maxInt returns the greater of two ints
+func maxInt(a, b int) int {
+	if a > b {
+		return a
 	}
+	return b
 }
 
 //Internal function used by Split function.
@@ -132,9 +225,14 @@ func (rope *Rope) split(idx int,
 		//We have to recurse on right side.
 		newRight, secondRope := rope.right.split(idx-rope.weight, secondRope)
 		return &Rope{
-			weight: rope.weight,
-			left:   rope.left,
-			right:  newRight,
+			weight:     rope.weight,
+			length:     rope.left.Len() + newRight.Len(),
+			byteWeight: rope.byteWeight,
+			byteLength: rope.left.ByteLen() + newRight.ByteLen(),
+			depth:      maxInt(rope.left.Depth(), newRight.Depth()) + 1,
+			lineCount:  rope.left.LineCount() + newRight.LineCount(),
+			left:       rope.left,
+			right:      newRight,
 		}, secondRope
 	} else {
 		//idx < rope.weight, we recurse on the left side
@@ -142,17 +240,9 @@ func (rope *Rope) split(idx int,
 			//It's a leaf: we have to create a new rope by splitting leaf at index
 			var lr *Rope
 			if idx > 0 {
-				lr = &Rope{
-					weight: idx,
-					value:  rope.value[0:idx],
-					length: idx,
-				}
-			}
-			secondRope = &Rope{
-				weight: len(rope.value) - idx,
-				value:  rope.value[idx:len(rope.value)],
-				length: len(rope.value) - idx,
+				lr = newLeaf(rope.value[0:idx])
 			}
+			secondRope = newLeaf(rope.value[idx:len(rope.value)])
 			return lr, secondRope
 		} else {
 			newLeft, secondRope := rope.left.split(idx, secondRope)
@@ -168,10 +258,10 @@ func (rope *Rope) Split(idx int) (firstRope *Rope, secondRope *Rope) {
 		return nil, nil
 	}
 	if idx <= 0 {
-		return rope, nil
+		return nil, rope
 	}
 	if idx >= rope.length {
-		return nil, rope
+		return rope, nil
 	}
 	//Create the slices for split
 	return rope.split(idx, secondRope)
@@ -235,9 +325,12 @@ func (rope *Rope) internalReport(idx int, length int, res []rune) {
 			rope.left.internalReport(idx, length, res)
 		}
 	} else {
-		//Split the work
-		rope.left.internalReport(idx, rope.weight-idx+1, res[:rope.weight])
-		rope.right.internalReport(1, length-rope.weight+idx-1, res[rope.weight:])
+		//Split the work. leftLen is how many of the requested runes fall in
+		//left, not rope.weight itself - idx may be > 1, so the boundary
+		//within res is leftLen, not rope.weight.
+		leftLen := rope.weight - idx + 1
+		rope.left.internalReport(idx, leftLen, res[:leftLen])
+		rope.right.internalReport(1, length-leftLen, res[leftLen:])
 	}
 }
 
@@ -36,4 +36,20 @@ func TestRopeSplit(t *testing.T) {
 	if r.String() != "abcdef" || r1.String() != "abcd" || r2.String() != "ef" {
 		t.Error("Error splitting string: abcd/ef => ", r1, r2)
 	}
+	if r1, r2 := r.Split(0); r1 != nil || r2.String() != "abcdef" {
+		t.Error("Error splitting at 0: ", r1, r2)
+	}
+	if r1, r2 := r.Split(r.Len()); r1.String() != "abcdef" || r2 != nil {
+		t.Error("Error splitting at length: ", r1, r2)
+	}
+}
+
+//Test splitting a multi-leaf rope at an index that falls within its
+//rightmost leaf, exercising the right-recursion branch of split
+func TestRopeSplitAcrossMultipleLeaves(t *testing.T) {
+	r := New("abc").Concat(New("def")).Concat(New("ghi"))
+	r1, r2 := r.Split(7)
+	if r1.String() != "abcdefg" || r1.Len() != 7 || r2.String() != "hi" || r2.Len() != 2 {
+		t.Error("Error splitting across multiple leaves: ", r1, r2)
+	}
 }
@@ -0,0 +1,286 @@
+package rope
+
+import (
+	"io"
+	"regexp"
+	"unicode/utf8"
+)
+
+//Match describes a regexp match in rope coordinates: Start is the 1-based
+//position of its first rune, End is the 1-based position one past its
+//last rune (so End-Start is the match length, as used by Substr/Delete).
+type Match struct {
+	Start int
+	End   int
+}
+
+//kmpFailure computes the Knuth-Morris-Pratt partial match table for pattern
+func kmpFailure(pattern []rune) []int {
+	failure := make([]int, len(pattern))
+	k := 0
+	for i := 1; i < len(pattern); i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = failure[k-1]
+		}
+		if pattern[i] == pattern[k] {
+			k++
+		}
+		failure[i] = k
+	}
+	return failure
+}
+
+//IndexOf returns the 1-based position of the first occurrence of pattern
+//at or after position from, streaming runes from a Cursor and matching
+//them against pattern with Knuth-Morris-Pratt, or -1 if pattern does not
+//occur. An empty pattern matches at from itself.
+func (rope *Rope) IndexOf(pattern string, from int) int {
+	if from < 1 {
+		from = 1
+	}
+	p := []rune(pattern)
+	if len(p) == 0 {
+		if from > rope.Len()+1 {
+			return -1
+		}
+		return from
+	}
+	failure := kmpFailure(p)
+	c := rope.Cursor(from)
+	j := 0
+	for {
+		ch, ok := c.Next()
+		if !ok {
+			return -1
+		}
+		for j > 0 && ch != p[j] {
+			j = failure[j-1]
+		}
+		if ch == p[j] {
+			j++
+		}
+		if j == len(p) {
+			return c.Pos() - len(p)
+		}
+	}
+}
+
+//FindAll returns the 1-based starting positions of every non-overlapping
+//occurrence of pattern in rope, left to right
+func (rope *Rope) FindAll(pattern string) []int {
+	step := len([]rune(pattern))
+	if step == 0 {
+		step = 1
+	}
+	var positions []int
+	for from := 1; ; {
+		idx := rope.IndexOf(pattern, from)
+		if idx == -1 {
+			return positions
+		}
+		positions = append(positions, idx)
+		from = idx + step
+	}
+}
+
+//globTokenKind identifies the kind of a compiled glob token
+type globTokenKind byte
+
+const (
+	globLiteral  globTokenKind = iota //a literal rune
+	globAny                           //'?': matches exactly one rune
+	globStarLine                      //'*': matches a run of runes, '\n' excluded
+	globStarAll                       //'**': matches a run of runes, '\n' included
+)
+
+type globToken struct {
+	kind globTokenKind
+	lit  rune
+}
+
+//compileGlob turns pattern into a token sequence: '?' matches exactly one
+//rune, '*' matches any run of runes that does not cross a line boundary,
+//and '**' matches any run of runes including line boundaries - the same
+//single-line/multi-line distinction LineRange and friends already draw.
+func compileGlob(pattern string) []globToken {
+	runes := []rune(pattern)
+	tokens := make([]globToken, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '?':
+			tokens = append(tokens, globToken{kind: globAny})
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				tokens = append(tokens, globToken{kind: globStarAll})
+				i++
+			} else {
+				tokens = append(tokens, globToken{kind: globStarLine})
+			}
+		default:
+			tokens = append(tokens, globToken{kind: globLiteral, lit: runes[i]})
+		}
+	}
+	return tokens
+}
+
+//globClosure extends states with every token index reachable without
+//consuming a rune, i.e. by skipping a star token
+func globClosure(states map[int]bool, tokens []globToken) map[int]bool {
+	for changed := true; changed; {
+		changed = false
+		for s := range states {
+			if s >= len(tokens) {
+				continue
+			}
+			if k := tokens[s].kind; k == globStarLine || k == globStarAll {
+				if !states[s+1] {
+					states[s+1] = true
+					changed = true
+				}
+			}
+		}
+	}
+	return states
+}
+
+//globStep advances states past one input rune
+func globStep(states map[int]bool, tokens []globToken, ch rune) map[int]bool {
+	next := map[int]bool{}
+	for s := range states {
+		if s >= len(tokens) {
+			continue
+		}
+		switch tokens[s].kind {
+		case globLiteral:
+			if ch == tokens[s].lit {
+				next[s+1] = true
+			}
+		case globAny:
+			next[s+1] = true
+		case globStarLine:
+			if ch != '\n' {
+				next[s] = true
+			}
+		case globStarAll:
+			next[s] = true
+		}
+	}
+	return next
+}
+
+//matchGlobFrom runs the compiled token NFA against runes pulled from
+//cursor, stopping as soon as the pattern is satisfied (the shortest match
+//starting at cursor's current position). It reports whether a match was
+//found and, if so, the position one past its last rune.
+func matchGlobFrom(cursor *Cursor, tokens []globToken) (bool, int) {
+	states := globClosure(map[int]bool{0: true}, tokens)
+	if states[len(tokens)] {
+		return true, cursor.Pos()
+	}
+	for {
+		ch, ok := cursor.Next()
+		if !ok {
+			return false, 0
+		}
+		states = globStep(states, tokens, ch)
+		if len(states) == 0 {
+			return false, 0
+		}
+		states = globClosure(states, tokens)
+		if states[len(tokens)] {
+			return true, cursor.Pos()
+		}
+	}
+}
+
+//MatchGlob returns the 1-based starting positions of every non-overlapping
+//match of the glob pattern in rope. pattern supports '?' (one rune), '*'
+//(a run of runes within a single line) and '**' (a run of runes spanning
+//lines); each match reported is the shortest one starting at its position.
+func (rope *Rope) MatchGlob(pattern string) []int {
+	tokens := compileGlob(pattern)
+	var positions []int
+	n := rope.Len()
+	for pos := 1; pos <= n+1; {
+		matched, end := matchGlobFrom(rope.Cursor(pos), tokens)
+		if !matched {
+			pos++
+			continue
+		}
+		positions = append(positions, pos)
+		if end > pos {
+			pos = end
+		} else {
+			pos++
+		}
+	}
+	return positions
+}
+
+//cursorRuneReader adapts a Cursor to io.RuneReader, for use with
+//regexp.Regexp.FindReaderIndex
+type cursorRuneReader struct {
+	cursor *Cursor
+}
+
+//ReadRune implements io.RuneReader
+func (r cursorRuneReader) ReadRune() (rune, int, error) {
+	ch, ok := r.cursor.Next()
+	if !ok {
+		return 0, 0, io.EOF
+	}
+	return ch, utf8.RuneLen(ch), nil
+}
+
+//runeCountsForByteOffsets converts byteOffsets (sorted ascending, relative
+//to the UTF-8 encoding of rope's text starting at position from) into the
+//equivalent rune counts from from, in a single forward pass
+func runeCountsForByteOffsets(rope *Rope, from int, byteOffsets []int) []int {
+	counts := make([]int, len(byteOffsets))
+	c := rope.Cursor(from)
+	bytePos, runeCount, oi := 0, 0, 0
+	for oi < len(byteOffsets) && byteOffsets[oi] <= bytePos {
+		counts[oi] = runeCount
+		oi++
+	}
+	for oi < len(byteOffsets) {
+		ch, ok := c.Next()
+		if !ok {
+			break
+		}
+		bytePos += utf8.RuneLen(ch)
+		runeCount++
+		for oi < len(byteOffsets) && byteOffsets[oi] <= bytePos {
+			counts[oi] = runeCount
+			oi++
+		}
+	}
+	for oi < len(byteOffsets) {
+		counts[oi] = runeCount
+		oi++
+	}
+	return counts
+}
+
+//FindRegexp returns every non-overlapping match of re in rope, left to
+//right, in rope's 1-based rune coordinates. It drives re over a
+//Cursor-backed io.RuneReader rather than materializing rope's text.
+func (rope *Rope) FindRegexp(re *regexp.Regexp) []Match {
+	var matches []Match
+	n := rope.Len()
+	for from := 1; from <= n+1; {
+		loc := re.FindReaderIndex(cursorRuneReader{cursor: rope.Cursor(from)})
+		if loc == nil {
+			break
+		}
+		counts := runeCountsForByteOffsets(rope, from, loc)
+		start, end := from+counts[0], from+counts[1]
+		matches = append(matches, Match{Start: start, End: end})
+		if end > start {
+			from = end
+		} else {
+			from = start + 1
+		}
+	}
+	return matches
+}
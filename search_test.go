@@ -0,0 +1,79 @@
+package rope
+
+import (
+	"regexp"
+	"testing"
+)
+
+//Test IndexOf finds the first occurrence at or after from, and reports -1
+//when pattern does not occur
+func TestIndexOf(t *testing.T) {
+	r := New("the cat sat on the mat")
+	if idx := r.IndexOf("the", 1); idx != 1 {
+		t.Error("Error in IndexOf: ", idx, "!= 1")
+	}
+	if idx := r.IndexOf("the", 5); idx != 16 {
+		t.Error("Error in IndexOf with from: ", idx, "!= 16")
+	}
+	if idx := r.IndexOf("zzz", 1); idx != -1 {
+		t.Error("Error in IndexOf for missing pattern: ", idx, "!= -1")
+	}
+}
+
+//Test FindAll returns every non-overlapping occurrence
+func TestFindAll(t *testing.T) {
+	r := New("aaaa")
+	if got := r.FindAll("aa"); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Error("Error in FindAll non-overlapping matches: ", got)
+	}
+	if got := New("abc").FindAll("z"); got != nil {
+		t.Error("Error in FindAll for missing pattern: ", got)
+	}
+}
+
+//Test MatchGlob's '?' and single-line '*' semantics
+func TestMatchGlob(t *testing.T) {
+	r := New("the cat sat")
+	if got := r.MatchGlob("t?e"); len(got) != 1 || got[0] != 1 {
+		t.Error("Error in MatchGlob with '?': ", got)
+	}
+	r2 := New("line one\nline two")
+	if got := r2.MatchGlob("one*two"); got != nil {
+		t.Error("Error: single-line '*' should not cross a newline: ", got)
+	}
+	if got := r2.MatchGlob("one**two"); len(got) != 1 {
+		t.Error("Error: '**' should cross a newline: ", got)
+	}
+}
+
+//Test FindRegexp returns matches in rune coordinates even with
+//multi-byte UTF-8 content preceding a match
+func TestFindRegexp(t *testing.T) {
+	r := New("a café nice café here")
+	re := regexp.MustCompile(`café`)
+	matches := r.FindRegexp(re)
+	if len(matches) != 2 {
+		t.Fatal("Error in FindRegexp match count: ", len(matches))
+	}
+	if r.Report(matches[0].Start, matches[0].End-matches[0].Start) != "café" {
+		t.Error("Error in first FindRegexp match: ", matches[0])
+	}
+	if r.Report(matches[1].Start, matches[1].End-matches[1].Start) != "café" {
+		t.Error("Error in second FindRegexp match: ", matches[1])
+	}
+	if New("nothing here").FindRegexp(re) != nil {
+		t.Error("Error: expected no matches")
+	}
+}
+
+//Test IndexOf and MatchGlob against a Concat-built rope whose match
+//straddles the leaf boundary, not just a single-leaf rope
+func TestSearchAcrossConcatBoundary(t *testing.T) {
+	r := New("the ca").Concat(New("t sat"))
+	if idx := r.IndexOf("cat", 1); idx != 5 {
+		t.Error("Error in IndexOf across boundary: ", idx, "!= 5")
+	}
+	if got := r.MatchGlob("c?t"); len(got) != 1 || got[0] != 5 {
+		t.Error("Error in MatchGlob across boundary: ", got)
+	}
+}